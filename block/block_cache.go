@@ -0,0 +1,51 @@
+package block
+
+import (
+	"sync"
+)
+
+// BlockCache tracks which block hashes have been seen and which have been
+// hard confirmed via DA, so the manager can dedup and report DA-inclusion
+// status without re-deriving it from the store on every check.
+type BlockCache struct {
+	mtx sync.RWMutex
+
+	hashes            map[string]bool
+	hardConfirmations map[string]bool
+}
+
+// NewBlockCache creates an empty BlockCache.
+func NewBlockCache() *BlockCache {
+	return &BlockCache{
+		hashes:            make(map[string]bool),
+		hardConfirmations: make(map[string]bool),
+	}
+}
+
+// IsSeen reports whether hash has already been observed, for dedup.
+func (c *BlockCache) IsSeen(hash string) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.hashes[hash]
+}
+
+// SetSeen marks hash as observed.
+func (c *BlockCache) SetSeen(hash string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.hashes[hash] = true
+}
+
+// SetHardConfirmed marks hash as included on the DA layer.
+func (c *BlockCache) SetHardConfirmed(hash string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.hardConfirmations[hash] = true
+}
+
+// IsHardConfirmed reports whether hash has been included on the DA layer.
+func (c *BlockCache) IsHardConfirmed(hash string) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.hardConfirmations[hash]
+}
@@ -0,0 +1,435 @@
+// Package block implements the block manager: the component responsible for
+// producing, syncing and applying blocks, and for tracking their DA
+// inclusion status.
+package block
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// blockProductionPollInterval is how often the aggregator loop checks
+// whether it's time to produce the next block. It is deliberately much
+// shorter than any realistic BlockTime so that tests can force immediate
+// production by rewinding State.LastBlockTime.
+const blockProductionPollInterval = 10 * time.Millisecond
+
+// Manager drives block production and application for a rollkit node. It
+// currently only implements the aggregator side: producing blocks and
+// submitting them to DA. The cache tracks seen/hard-confirmed hashes for
+// dedup and DA-inclusion bookkeeping.
+type Manager struct {
+	store     store.Store
+	executor  execution.Executor
+	sequencer *coresequencer.DummySequencer
+	da        *coreda.DummyDA
+	signer    *noop.Signer
+	logger    log.Logger
+
+	aggregator       bool
+	blockTime        time.Duration
+	daBlockTime      time.Duration
+	maxPendingBlocks uint64
+	proposerAddress  []byte
+
+	cache *BlockCache
+
+	pendingHeaders *PendingHeaders
+
+	mtx              sync.Mutex
+	lastState        State
+	nextApplyHeight  uint64
+	daIncludedHeight uint64
+	heightToHash     map[uint64]string
+}
+
+// NewManager creates a Manager backed by the given store, executor,
+// sequencer and DA client, configured per cfg. signer and proposerAddress
+// are used to sign produced blocks; signer may be nil, in which case
+// produced blocks are left unsigned.
+func NewManager(store store.Store, executor execution.Executor, sequencer *coresequencer.DummySequencer, da *coreda.DummyDA, signer *noop.Signer, proposerAddress []byte, cfg rollkitconfig.Config, logger log.Logger) *Manager {
+	return &Manager{
+		store:            store,
+		executor:         executor,
+		sequencer:        sequencer,
+		da:               da,
+		signer:           signer,
+		logger:           logger,
+		aggregator:       cfg.Node.Aggregator,
+		blockTime:        cfg.Node.BlockTime.Duration,
+		daBlockTime:      cfg.DA.BlockTime.Duration,
+		maxPendingBlocks: cfg.Node.MaxPendingBlocks,
+		proposerAddress:  proposerAddress,
+		cache:            NewBlockCache(),
+		pendingHeaders:   NewPendingHeaders(),
+		nextApplyHeight:  1,
+		heightToHash:     make(map[uint64]string),
+	}
+}
+
+// Run starts the aggregator loop: producing blocks on BlockTime, and
+// submitting pending ones to DA on DA.BlockTime. If the manager isn't
+// configured as an aggregator, it simply blocks until ctx is canceled; there
+// is no sync-from-P2P/DA path yet.
+func (m *Manager) Run(ctx context.Context) error {
+	if err := m.recoverFromStore(ctx); err != nil {
+		return fmt.Errorf("recovering from store: %w", err)
+	}
+
+	if !m.aggregator {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	state := m.GetLastState()
+	if len(state.AppHash) == 0 {
+		stateRoot, _, err := m.executor.InitChain(ctx, time.Now().Unix(), state.InitialHeight, state.ChainID)
+		if err != nil {
+			return fmt.Errorf("initializing chain: %w", err)
+		}
+		state.AppHash = stateRoot
+		m.SetLastState(state)
+	}
+
+	blockPoll := time.NewTicker(blockProductionPollInterval)
+	defer blockPoll.Stop()
+	daTicker := time.NewTicker(m.daBlockTime)
+	defer daTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-blockPoll.C:
+			if time.Since(m.GetLastState().LastBlockTime) < m.blockTime {
+				continue
+			}
+			if err := m.produceBlock(ctx); err != nil {
+				m.logger.Error("failed to produce block", "err", err)
+			}
+		case <-daTicker.C:
+			if err := m.submitPendingToDA(ctx); err != nil {
+				m.logger.Error("failed to submit pending blocks to DA", "err", err)
+			}
+		}
+	}
+}
+
+// recoverFromStore rebuilds in-memory state (nextApplyHeight, the seen/
+// hard-confirmed cache, heightToHash and pendingHeaders) from whatever was
+// durably persisted, so that a restart picks up exactly where the previous
+// process left off instead of losing everything that wasn't DA-confirmed
+// yet. It is called once at the start of Run.
+func (m *Manager) recoverFromStore(ctx context.Context) error {
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("reading store height: %w", err)
+	}
+	if height == 0 {
+		return nil
+	}
+
+	m.mtx.Lock()
+	m.nextApplyHeight = height + 1
+	m.mtx.Unlock()
+
+	var tipAppHash []byte
+	for h := uint64(1); h <= height; h++ {
+		block, err := m.store.GetBlock(ctx, h)
+		if err != nil {
+			if err == store.ErrBlockNotFound {
+				continue // pruned
+			}
+			return fmt.Errorf("loading block at height %d: %w", h, err)
+		}
+		tipAppHash = block.SignedHeader.Header.AppHash
+
+		source, err := m.store.GetBlockSource(ctx, h)
+		if err != nil {
+			return fmt.Errorf("loading block source at height %d: %w", h, err)
+		}
+
+		hash := string(block.Hash())
+		m.cache.SetSeen(hash)
+		m.recordHeightHash(h, hash)
+
+		if source == types.SourceDA {
+			m.cache.SetHardConfirmed(hash)
+			m.pendingHeaders.MarkSubmitted(h)
+		} else {
+			m.pendingHeaders.Add(h)
+		}
+	}
+
+	if len(tipAppHash) > 0 {
+		state := m.GetLastState()
+		state.AppHash = tipAppHash
+		m.SetLastState(state)
+	}
+
+	m.mtx.Lock()
+	m.recomputeDAIncludedHeight()
+	m.mtx.Unlock()
+
+	return nil
+}
+
+// produceBlock builds the next block from whatever transactions the
+// executor has available, executes it, applies it locally and queues it for
+// DA submission. It is a no-op (skipping this round) if MaxPendingBlocks
+// pending, not-yet-DA-submitted headers have already piled up.
+func (m *Manager) produceBlock(ctx context.Context) error {
+	pending, err := m.pendingHeaders.GetPendingHeaders()
+	if err != nil {
+		return fmt.Errorf("listing pending headers: %w", err)
+	}
+	if m.maxPendingBlocks > 0 && uint64(len(pending)) >= m.maxPendingBlocks {
+		return nil
+	}
+
+	txs, err := m.executor.GetTxs(ctx)
+	if err != nil {
+		return fmt.Errorf("getting txs: %w", err)
+	}
+
+	m.mtx.Lock()
+	height := m.nextApplyHeight
+	m.mtx.Unlock()
+
+	state := m.GetLastState()
+	data := types.Data{Txs: txs}
+	block := &types.Block{
+		SignedHeader: types.SignedHeader{
+			Header: types.Header{
+				Height:          height,
+				Time:            uint64(time.Now().UnixNano()),
+				ChainID:         state.ChainID,
+				DataHash:        data.Hash(),
+				ProposerAddress: m.proposerAddress,
+			},
+			Validators: m.proposerAddress,
+		},
+		Data: data,
+	}
+
+	stateRoot, err := m.executeAndCommit(ctx, block, state.AppHash)
+	if err != nil {
+		return err
+	}
+	block.SignedHeader.Header.AppHash = stateRoot
+
+	if m.signer != nil {
+		sig, err := m.signer.Sign(block.SignedHeader.Header.Hash())
+		if err != nil {
+			return fmt.Errorf("signing block at height %d: %w", height, err)
+		}
+		block.SignedHeader.Signature = sig
+	}
+
+	if err := m.applyProducedBlock(ctx, block); err != nil {
+		return err
+	}
+
+	state.LastBlockTime = time.Now()
+	state.AppHash = stateRoot
+	m.SetLastState(state)
+
+	return nil
+}
+
+// submitPendingToDA submits every not-yet-submitted pending header to the DA
+// layer and marks it hard confirmed once accepted. DummyDA never interprets
+// its blobs, so the block hash alone is submitted.
+func (m *Manager) submitPendingToDA(ctx context.Context) error {
+	pending, err := m.pendingHeaders.GetPendingHeaders()
+	if err != nil {
+		return fmt.Errorf("listing pending headers: %w", err)
+	}
+
+	for _, height := range pending {
+		block, err := m.store.GetBlock(ctx, height)
+		if err != nil {
+			m.logger.Error("failed to load pending block for DA submission", "height", height, "err", err)
+			continue
+		}
+
+		hash := block.Hash()
+		if _, err := m.da.Submit(ctx, [][]byte{hash}); err != nil {
+			m.logger.Error("failed to submit block to DA", "height", height, "err", err)
+			continue
+		}
+
+		m.ConfirmDASubmission(ctx, height, string(hash))
+	}
+
+	return nil
+}
+
+// PendingHeaders returns the tracker for headers not yet confirmed
+// submitted to DA.
+func (m *Manager) PendingHeaders() *PendingHeaders {
+	return m.pendingHeaders
+}
+
+// SeqClient returns the sequencer client the manager pulls batches from.
+func (m *Manager) SeqClient() *coresequencer.DummySequencer {
+	return m.sequencer
+}
+
+// PruneBlocks deletes headers, data, commits and state responses below
+// retainHeight, bounded by GetDAIncludedHeight so we never prune a height
+// that hasn't been hard-confirmed on DA yet.
+func (m *Manager) PruneBlocks(ctx context.Context, retainHeight uint64) (uint64, error) {
+	if daHeight := m.GetDAIncludedHeight(); retainHeight > daHeight {
+		retainHeight = daHeight
+	}
+	if retainHeight == 0 {
+		return 0, nil
+	}
+
+	pruned, err := m.store.PruneBlocks(ctx, retainHeight)
+	if err != nil {
+		return 0, fmt.Errorf("pruning blocks below %d: %w", retainHeight, err)
+	}
+
+	m.logger.Info("pruned blocks", "retain_height", retainHeight, "count", pruned)
+	return pruned, nil
+}
+
+// AppliedHeight returns the height of the newest block the manager has
+// applied so far this process, read from in-memory state rather than by
+// querying the store.
+func (m *Manager) AppliedHeight() uint64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.nextApplyHeight - 1
+}
+
+// GetDAIncludedHeight returns the highest height H such that every hash at
+// or below H has been hard confirmed on the DA layer.
+func (m *Manager) GetDAIncludedHeight() uint64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.daIncludedHeight
+}
+
+// IsHeightHardConfirmed reports whether the block we've seen at height has
+// been hard confirmed via DA, by hash rather than by the (coarser)
+// contiguous DA-included height.
+func (m *Manager) IsHeightHardConfirmed(height uint64) bool {
+	m.mtx.Lock()
+	hash, known := m.heightToHash[height]
+	m.mtx.Unlock()
+	if !known {
+		return false
+	}
+	return m.cache.IsHardConfirmed(hash)
+}
+
+// recomputeDAIncludedHeight advances daIncludedHeight past every
+// contiguous, hard-confirmed height starting right after it. Must be called
+// with mtx held.
+func (m *Manager) recomputeDAIncludedHeight() {
+	for {
+		next := m.daIncludedHeight + 1
+		hash, known := m.heightToHash[next]
+		if !known || !m.cache.IsHardConfirmed(hash) {
+			return
+		}
+		m.daIncludedHeight = next
+	}
+}
+
+func (m *Manager) recordHeightHash(height uint64, hash string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.heightToHash[height] = hash
+	m.recomputeDAIncludedHeight()
+}
+
+// applyBlock persists block and records how it was obtained, so that later
+// callers (and tests) can distinguish a height that was merely gossiped over
+// P2P from one that was hard-confirmed via DA.
+func (m *Manager) applyBlock(ctx context.Context, block *types.Block, source types.BlockSource) error {
+	height := block.Height()
+
+	if err := m.store.SaveBlock(ctx, block, block.SignedHeader.Signature); err != nil {
+		return fmt.Errorf("saving block at height %d: %w", height, err)
+	}
+
+	if err := m.store.SaveBlockSource(ctx, height, source); err != nil {
+		return fmt.Errorf("saving block source for height %d: %w", height, err)
+	}
+
+	m.logger.Debug("applied block", "height", height, "source", source.String())
+	return nil
+}
+
+// applyProducedBlock is called by the aggregator loop right after it builds
+// and signs a new block itself, bypassing the cache since the block is
+// known to be next and is not shared with any other path yet.
+func (m *Manager) applyProducedBlock(ctx context.Context, block *types.Block) error {
+	if err := m.applyBlock(ctx, block, types.SourceProduced); err != nil {
+		return err
+	}
+
+	m.mtx.Lock()
+	if block.Height() == m.nextApplyHeight {
+		m.nextApplyHeight++
+	}
+	m.mtx.Unlock()
+
+	m.pendingHeaders.Add(block.Height())
+
+	return nil
+}
+
+// ConfirmDASubmission marks height (and the produced block's hash) as hard
+// confirmed once its DA submission lands, advancing GetDAIncludedHeight and
+// updating the recorded block source to SourceDA.
+func (m *Manager) ConfirmDASubmission(ctx context.Context, height uint64, hash string) {
+	m.cache.SetHardConfirmed(hash)
+
+	m.mtx.Lock()
+	m.heightToHash[height] = hash
+	m.recomputeDAIncludedHeight()
+	m.mtx.Unlock()
+
+	m.pendingHeaders.MarkSubmitted(height)
+
+	if err := m.store.SaveBlockSource(ctx, height, types.SourceDA); err != nil {
+		m.logger.Error("failed to update block source to DA after submission", "height", height, "err", err)
+	}
+}
+
+// executeAndCommit hands the block's transactions to the executor, and
+// consumes its RetainHeight hint (mirroring ABCI's ResponseCommit) by
+// pruning the store down to it, bounded by DA inclusion. It returns the
+// updated state root.
+func (m *Manager) executeAndCommit(ctx context.Context, block *types.Block, prevStateRoot []byte) ([]byte, error) {
+	header := block.SignedHeader.Header
+	stateRoot, retainHeight, err := m.executor.ExecuteTxs(ctx, block.Data.Txs, header.Height, int64(header.Time), prevStateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("executing txs at height %d: %w", header.Height, err)
+	}
+
+	if retainHeight > 0 {
+		if _, err := m.PruneBlocks(ctx, retainHeight); err != nil {
+			m.logger.Error("failed to prune blocks from executor retain height hint", "retain_height", retainHeight, "err", err)
+		}
+	}
+
+	return stateRoot, nil
+}
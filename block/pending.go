@@ -0,0 +1,59 @@
+package block
+
+import "sync"
+
+// PendingHeaders tracks headers that have been produced/synced but not yet
+// confirmed as submitted to the DA layer.
+type PendingHeaders struct {
+	mtx                 sync.Mutex
+	pending             []uint64
+	lastSubmittedHeight uint64
+}
+
+// NewPendingHeaders creates an empty PendingHeaders tracker.
+func NewPendingHeaders() *PendingHeaders {
+	return &PendingHeaders{}
+}
+
+// GetPendingHeaders returns the heights not yet confirmed submitted to DA.
+func (p *PendingHeaders) GetPendingHeaders() ([]uint64, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	out := make([]uint64, len(p.pending))
+	copy(out, p.pending)
+	return out, nil
+}
+
+// GetLastSubmittedHeight returns the highest height confirmed submitted to
+// DA.
+func (p *PendingHeaders) GetLastSubmittedHeight() uint64 {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.lastSubmittedHeight
+}
+
+// Add records height as pending DA submission.
+func (p *PendingHeaders) Add(height uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.pending = append(p.pending, height)
+}
+
+// MarkSubmitted drops height from the pending set and advances
+// lastSubmittedHeight if height is now the highest confirmed.
+func (p *PendingHeaders) MarkSubmitted(height uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	filtered := p.pending[:0]
+	for _, h := range p.pending {
+		if h != height {
+			filtered = append(filtered, h)
+		}
+	}
+	p.pending = filtered
+
+	if height > p.lastSubmittedHeight {
+		p.lastSubmittedHeight = height
+	}
+}
@@ -0,0 +1,27 @@
+package block
+
+import "time"
+
+// State is the chain state the block manager uses to decide when to
+// produce the next block.
+type State struct {
+	ChainID       string
+	InitialHeight uint64
+	LastBlockTime time.Time
+	AppHash       []byte
+}
+
+// GetLastState returns the block manager's current view of chain state.
+func (m *Manager) GetLastState() State {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.lastState
+}
+
+// SetLastState overwrites the block manager's view of chain state, used by
+// tests to force the aggregator loop to consider it due for a new block.
+func (m *Manager) SetLastState(state State) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.lastState = state
+}
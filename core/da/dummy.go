@@ -0,0 +1,51 @@
+// Package da defines the interface rollkit uses to submit and retrieve
+// blobs from a data-availability layer.
+package da
+
+import (
+	"context"
+	"sync"
+)
+
+// DummyDA is an in-memory DA layer used by tests: submitted blobs are
+// immediately available for retrieval.
+type DummyDA struct {
+	mu            sync.Mutex
+	maxBlobSize   int
+	gasPrice      float64
+	gasMultiplier float64
+
+	blobs [][]byte
+}
+
+// NewDummyDA creates a DummyDA with the given max blob size and gas
+// parameters (unused beyond bookkeeping, since DummyDA never actually
+// charges gas).
+func NewDummyDA(maxBlobSize int, gasPrice, gasMultiplier float64) *DummyDA {
+	return &DummyDA{maxBlobSize: maxBlobSize, gasPrice: gasPrice, gasMultiplier: gasMultiplier}
+}
+
+// Submit appends blobs to the DA layer and returns their assigned IDs
+// (simply their index).
+func (d *DummyDA) Submit(ctx context.Context, blobs [][]byte) ([]uint64, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ids := make([]uint64, len(blobs))
+	for i, b := range blobs {
+		ids[i] = uint64(len(d.blobs))
+		d.blobs = append(d.blobs, b)
+	}
+	return ids, nil
+}
+
+// Retrieve returns the blob previously submitted under id.
+func (d *DummyDA) Retrieve(ctx context.Context, id uint64) ([]byte, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if id >= uint64(len(d.blobs)) {
+		return nil, context.DeadlineExceeded
+	}
+	return d.blobs[id], nil
+}
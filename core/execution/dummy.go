@@ -0,0 +1,61 @@
+package execution
+
+import (
+	"context"
+	"sync"
+)
+
+// DummyExecutor is a trivial Executor used by tests: it accepts injected
+// transactions verbatim and never actually changes state.
+type DummyExecutor struct {
+	mu           sync.Mutex
+	injectedTxs  [][]byte
+	retainHeight uint64
+}
+
+// NewDummyExecutor creates an empty DummyExecutor.
+func NewDummyExecutor() *DummyExecutor {
+	return &DummyExecutor{}
+}
+
+// InjectTx queues a transaction to be returned by the next GetTxs call.
+func (e *DummyExecutor) InjectTx(tx []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.injectedTxs = append(e.injectedTxs, tx)
+}
+
+// SetRetainHeight configures the retain height hint returned by subsequent
+// ExecuteTxs calls, simulating an ABCI app that wants the node to prune
+// everything below it.
+func (e *DummyExecutor) SetRetainHeight(height uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.retainHeight = height
+}
+
+// InitChain implements Executor.
+func (e *DummyExecutor) InitChain(ctx context.Context, genesisTime int64, initialHeight uint64, chainID string) ([]byte, uint64, error) {
+	return []byte("genesis-state-root"), 1024 * 1024, nil
+}
+
+// GetTxs implements Executor.
+func (e *DummyExecutor) GetTxs(ctx context.Context) ([][]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	txs := e.injectedTxs
+	e.injectedTxs = nil
+	return txs, nil
+}
+
+// ExecuteTxs implements Executor.
+func (e *DummyExecutor) ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight uint64, timestamp int64, prevStateRoot []byte) ([]byte, uint64, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return prevStateRoot, e.retainHeight, nil
+}
+
+// SetFinal implements Executor.
+func (e *DummyExecutor) SetFinal(ctx context.Context, blockHeight uint64) error {
+	return nil
+}
@@ -0,0 +1,27 @@
+// Package execution defines the interface rollkit uses to hand transactions
+// to (and sync state from) an execution environment, independent of any
+// particular chain application.
+package execution
+
+import "context"
+
+// Executor is implemented by execution environments the block manager
+// drives: it applies transactions and reports back the resulting state.
+type Executor interface {
+	// InitChain initializes the execution environment's genesis state.
+	InitChain(ctx context.Context, genesisTime int64, initialHeight uint64, chainID string) (stateRoot []byte, maxBytes uint64, err error)
+
+	// GetTxs returns transactions available for inclusion in the next
+	// block.
+	GetTxs(ctx context.Context) ([][]byte, error)
+
+	// ExecuteTxs executes the given transactions against prevStateRoot and
+	// returns the new state root. RetainHeight is a hint from the execution
+	// environment for how low the rollkit store may safely be pruned to
+	// (mirroring Tendermint/ABCI's ResponseCommit.RetainHeight): 0 means no
+	// pruning hint is given.
+	ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight uint64, timestamp int64, prevStateRoot []byte) (updatedStateRoot []byte, retainHeight uint64, err error)
+
+	// SetFinal marks the block at height as final/committed.
+	SetFinal(ctx context.Context, blockHeight uint64) error
+}
@@ -0,0 +1,36 @@
+// Package sequencer defines the interface rollkit uses to pull ordered
+// batches of transactions for block production.
+package sequencer
+
+import (
+	"context"
+	"sync"
+)
+
+// DummySequencer is an in-memory, FIFO sequencer used by tests.
+type DummySequencer struct {
+	mu    sync.Mutex
+	queue [][]byte
+}
+
+// NewDummySequencer creates an empty DummySequencer.
+func NewDummySequencer() *DummySequencer {
+	return &DummySequencer{}
+}
+
+// SubmitBatch enqueues a batch of transactions.
+func (s *DummySequencer) SubmitBatch(ctx context.Context, txs [][]byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, txs...)
+	return nil
+}
+
+// GetNextBatch dequeues and returns everything currently queued.
+func (s *DummySequencer) GetNextBatch(ctx context.Context) ([][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batch := s.queue
+	s.queue = nil
+	return batch, nil
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+const defaultLightProviderListenAddr = ":26661"
+
+// newLightProviderServer builds the HTTP handler the light-client provider
+// talks to: signed headers and validator-set info by height, read straight
+// from the node's Store.
+func newLightProviderServer(n *FullNode) *http.Server {
+	addr := n.nodeConfig.Node.LightProviderListenAddr
+	if addr == "" {
+		addr = defaultLightProviderListenAddr
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/light/header", n.handleLightHeader)
+	mux.HandleFunc("/light/validators", n.handleLightValidators)
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+func (n *FullNode) lightHeight(r *http.Request) (uint64, error) {
+	raw := r.URL.Query().Get("height")
+	if raw == "" || raw == "0" {
+		return n.Store.Height(r.Context())
+	}
+	return strconv.ParseUint(raw, 10, 64)
+}
+
+func (n *FullNode) handleLightHeader(w http.ResponseWriter, r *http.Request) {
+	height, err := n.lightHeight(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	block, err := n.Store.GetBlock(r.Context(), height)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(block.SignedHeader)
+}
+
+func (n *FullNode) handleLightValidators(w http.ResponseWriter, r *http.Request) {
+	height, err := n.lightHeight(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	block, err := n.Store.GetBlock(r.Context(), height)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Validators []byte `json:"validators"`
+	}{Validators: block.SignedHeader.Validators})
+}
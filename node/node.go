@@ -0,0 +1,105 @@
+package node
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// Node is the interface satisfied by every runnable rollkit node kind
+// (currently just FullNode).
+type Node interface {
+	// Run starts the node's background loops and blocks until ctx is
+	// canceled.
+	Run(ctx context.Context) error
+}
+
+// HeightKind selects which notion of "current height" getNodeHeight reports.
+type HeightKind int
+
+const (
+	// Header is the height of the newest header the node has, regardless
+	// of whether it has been durably persisted yet.
+	Header HeightKind = iota
+	// Store is the height durably persisted in the node's Store.
+	Store
+)
+
+// getNodeHeight returns node's current height, per kind.
+func getNodeHeight(node *FullNode, kind HeightKind) (uint64, error) {
+	switch kind {
+	case Store:
+		return node.Store.Height(context.Background())
+	default:
+		return node.blockManager.AppliedHeight(), nil
+	}
+}
+
+// InitFiles ensures rootDir exists, creating it (and any parents) if
+// necessary.
+func InitFiles(rootDir string) error {
+	return os.MkdirAll(filepath.Join(rootDir, "data"), 0o755)
+}
+
+// MetricsProvider builds the metrics a node reports for a given chain ID.
+type MetricsProvider func(chainID string) any
+
+// DefaultMetricsProvider returns a MetricsProvider that reports metrics
+// when instrumentation is enabled, and nothing otherwise.
+func DefaultMetricsProvider(instr rollkitconfig.InstrumentationConfig) MetricsProvider {
+	return func(chainID string) any {
+		if !instr.Prometheus {
+			return nil
+		}
+		return struct{ ChainID string }{ChainID: chainID}
+	}
+}
+
+// NewNode builds a FullNode from its dependencies.
+func NewNode(
+	ctx context.Context,
+	cfg rollkitconfig.Config,
+	executor *coreexecutor.DummyExecutor,
+	sequencer *coresequencer.DummySequencer,
+	da *coreda.DummyDA,
+	signer *noop.Signer,
+	nodeKey key.NodeKey,
+	p2pClient *p2p.Client,
+	genesis types.Genesis,
+	datastore ds.Datastore,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+) (Node, error) {
+	_ = nodeKey
+	_ = p2pClient
+	_ = metricsProvider
+
+	nodeStore := store.New(datastore)
+	manager := block.NewManager(nodeStore, executor, sequencer, da, signer, genesis.ProposerAddress, cfg, logger)
+	manager.SetLastState(block.State{
+		ChainID:       genesis.ChainID,
+		InitialHeight: genesis.InitialHeight,
+	})
+
+	return &FullNode{
+		Store:        nodeStore,
+		blockManager: manager,
+		nodeConfig:   cfg,
+		genesis:      genesis,
+		datastore:    datastore,
+	}, nil
+}
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	badger "github.com/ipfs/go-ds-badger4"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+)
+
+// datastoreFactory builds the datastore backing a test node's Store, rooted
+// at rootDir. Tests that only care about a single node's lifetime can use
+// an in-memory one; tests that restart a node and need its state to
+// survive need a real on-disk one.
+type datastoreFactory func(rootDir string) (ds.Datastore, error)
+
+// inMemoryDatastore is the default factory: fast, but wiped on every call,
+// so it cannot be used across a simulated node restart.
+func inMemoryDatastore(rootDir string) (ds.Datastore, error) {
+	return dssync.MutexWrap(ds.NewMapDatastore()), nil
+}
+
+// badgerDatastore persists under <rootDir>/data so that calling it again
+// with the same rootDir reopens the same on-disk state, as a real node
+// restart would.
+func badgerDatastore(rootDir string) (ds.Datastore, error) {
+	return badger.NewDatastore(filepath.Join(rootDir, "data"), nil)
+}
+
+func getTestConfig(t *testing.T, index int) rollkitconfig.Config {
+	t.Helper()
+
+	config := rollkitconfig.Config{
+		RootDir: t.TempDir(),
+	}
+	config.Node.BlockTime.Duration = 200 * time.Millisecond
+	config.DA.BlockTime.Duration = 500 * time.Millisecond
+	config.Node.MaxPendingBlocks = 100
+
+	return config
+}
+
+// createTestComponents builds the dummy executor/sequencer/DA and a fresh
+// P2P client for a test node, along with a node datastore built by
+// newDatastore. Most tests pass inMemoryDatastore; tests that need state to
+// survive a simulated restart pass badgerDatastore with the same rootDir
+// across both the original and the restarted node.
+func createTestComponents(t *testing.T, newDatastore datastoreFactory) (*coreexecutor.DummyExecutor, *coresequencer.DummySequencer, *coreda.DummyDA, *p2p.Client, ds.Datastore) {
+	t.Helper()
+	require := require.New(t)
+
+	config := rollkitconfig.Config{RootDir: t.TempDir()}
+
+	executor := coreexecutor.NewDummyExecutor()
+	sequencer := coresequencer.NewDummySequencer()
+	dac := coreda.NewDummyDA(100_000, 0, 0)
+
+	nodeKey, err := key.GenerateNodeKey()
+	require.NoError(err)
+
+	p2pClient, err := p2p.NewClient(config, nodeKey, dssync.MutexWrap(ds.NewMapDatastore()), log.NewTestLogger(t), p2p.NopMetrics())
+	require.NoError(err)
+
+	store, err := newDatastore(config.RootDir)
+	require.NoError(err)
+
+	return executor, sequencer, dac, p2pClient, store
+}
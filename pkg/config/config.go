@@ -0,0 +1,54 @@
+// Package config defines the configuration surface for a rollkit node.
+package config
+
+import "time"
+
+// DurationConfig wraps time.Duration so it can carry its own TOML/CLI flag
+// parsing without every caller needing to know the on-disk representation.
+type DurationConfig struct {
+	Duration time.Duration
+}
+
+// NodeConfig holds the node-level (as opposed to DA-level) settings.
+type NodeConfig struct {
+	BlockTime        DurationConfig
+	MaxPendingBlocks uint64
+	Aggregator       bool
+
+	// LightProvider enables the light-client HTTP provider endpoint, which
+	// serves signed headers, commits and validator-set info by height so
+	// that lightweight clients and relayers can verify rollup state
+	// without running a full node.
+	LightProvider bool
+	// LightProviderListenAddr is the address the light-client HTTP provider
+	// endpoint listens on, when LightProvider is enabled. Defaults to
+	// ":26661" if empty.
+	LightProviderListenAddr string
+}
+
+// DAConfig holds settings for how the node talks to the DA layer.
+type DAConfig struct {
+	BlockTime DurationConfig
+}
+
+// InstrumentationConfig holds metrics/telemetry settings.
+type InstrumentationConfig struct {
+	Prometheus bool
+}
+
+// DefaultInstrumentationConfig returns the default instrumentation
+// settings: metrics disabled.
+func DefaultInstrumentationConfig() InstrumentationConfig {
+	return InstrumentationConfig{Prometheus: false}
+}
+
+// Config is the root node configuration.
+type Config struct {
+	RootDir string
+	ChainID string
+
+	Node NodeConfig
+	DA   DAConfig
+
+	Instrumentation InstrumentationConfig
+}
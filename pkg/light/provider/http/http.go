@@ -0,0 +1,79 @@
+// Package http implements a light.Provider over plain HTTP against a
+// rollkit full node's light-client endpoints, modeled on Tendermint's
+// light/provider/http.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rollkit/rollkit/pkg/light/provider"
+	"github.com/rollkit/rollkit/types"
+)
+
+// Provider fetches signed headers and validator sets from a single remote
+// rollkit full node over HTTP.
+type Provider struct {
+	chainID string
+	remote  string
+	client  *http.Client
+}
+
+var _ provider.Provider = (*Provider)(nil)
+
+// New creates a Provider that talks to remote (e.g. "http://localhost:7331")
+// and expects it to serve chainID.
+func New(chainID, remote string) (*Provider, error) {
+	if remote == "" {
+		return nil, fmt.Errorf("remote address must not be empty")
+	}
+	return &Provider{chainID: chainID, remote: remote, client: http.DefaultClient}, nil
+}
+
+// ChainID returns the chain ID this provider was constructed for.
+func (p *Provider) ChainID() string {
+	return p.chainID
+}
+
+// SignedHeader fetches the signed header at height from the remote node, or
+// the latest one if height is 0.
+func (p *Provider) SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error) {
+	var header types.SignedHeader
+	if err := p.get(ctx, fmt.Sprintf("%s/light/header?height=%d", p.remote, height), &header); err != nil {
+		return nil, fmt.Errorf("fetching signed header at height %d: %w", height, err)
+	}
+	return &header, nil
+}
+
+// ValidatorSet fetches the validator-set bytes in effect at height, or at
+// the latest height if height is 0.
+func (p *Provider) ValidatorSet(ctx context.Context, height uint64) ([]byte, error) {
+	var resp struct {
+		Validators []byte `json:"validators"`
+	}
+	if err := p.get(ctx, fmt.Sprintf("%s/light/validators?height=%d", p.remote, height), &resp); err != nil {
+		return nil, fmt.Errorf("fetching validator set at height %d: %w", height, err)
+	}
+	return resp.Validators, nil
+}
+
+func (p *Provider) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
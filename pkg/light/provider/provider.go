@@ -0,0 +1,26 @@
+// Package provider defines the interface lightweight clients use to fetch
+// verifiable chain state from a rollkit full node, modeled on Tendermint's
+// light/provider.
+package provider
+
+import (
+	"context"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Provider is implemented by light-client data sources: an HTTP client
+// talking to a full node's RPC, a p2p provider, or (in tests) an in-memory
+// fixture.
+type Provider interface {
+	// ChainID returns the chain ID this provider serves.
+	ChainID() string
+
+	// SignedHeader returns the signed header at height, or the latest one
+	// if height is 0.
+	SignedHeader(ctx context.Context, height uint64) (*types.SignedHeader, error)
+
+	// ValidatorSet returns the raw validator-set bytes in effect at height,
+	// or at the latest height if height is 0.
+	ValidatorSet(ctx context.Context, height uint64) ([]byte, error)
+}
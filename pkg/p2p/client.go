@@ -0,0 +1,40 @@
+// Package p2p implements the gossip network rollkit nodes use to exchange
+// blocks ahead of (or independent of) DA inclusion.
+package p2p
+
+import (
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+)
+
+// Metrics is the set of P2P metrics rollkit reports; NopMetrics returns a
+// no-op implementation for tests.
+type Metrics struct{}
+
+// NopMetrics returns a Metrics value that records nothing.
+func NopMetrics() Metrics {
+	return Metrics{}
+}
+
+// Client is a node's P2P gossip client.
+type Client struct {
+	config  config.Config
+	nodeKey *key.NodeKey
+	ds      ds.Datastore
+	logger  log.Logger
+	metrics Metrics
+}
+
+// NewClient creates a P2P Client for the given config and identity.
+func NewClient(cfg config.Config, nodeKey *key.NodeKey, datastore ds.Datastore, logger log.Logger, metrics Metrics) (*Client, error) {
+	return &Client{
+		config:  cfg,
+		nodeKey: nodeKey,
+		ds:      datastore,
+		logger:  logger,
+		metrics: metrics,
+	}, nil
+}
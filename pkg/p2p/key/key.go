@@ -0,0 +1,19 @@
+// Package key manages the node's P2P identity key.
+package key
+
+import "github.com/libp2p/go-libp2p/core/crypto"
+
+// NodeKey is the P2P identity of a rollkit node.
+type NodeKey struct {
+	PrivKey crypto.PrivKey
+	PubKey  crypto.PubKey
+}
+
+// GenerateNodeKey creates a fresh NodeKey, used in tests and `init`.
+func GenerateNodeKey() (*NodeKey, error) {
+	priv, pub, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeKey{PrivKey: priv, PubKey: pub}, nil
+}
@@ -0,0 +1,26 @@
+// Package noop provides a signer that signs with an in-memory key directly,
+// without talking to any external signing service. It exists for tests and
+// single-process deployments.
+package noop
+
+import "github.com/libp2p/go-libp2p/core/crypto"
+
+// Signer signs payloads using an in-memory private key.
+type Signer struct {
+	privKey crypto.PrivKey
+}
+
+// NewNoopSigner wraps privKey in a Signer.
+func NewNoopSigner(privKey crypto.PrivKey) (*Signer, error) {
+	return &Signer{privKey: privKey}, nil
+}
+
+// Sign signs payload with the wrapped private key.
+func (s *Signer) Sign(payload []byte) ([]byte, error) {
+	return s.privKey.Sign(payload)
+}
+
+// Pubkey returns the public key corresponding to the wrapped private key.
+func (s *Signer) Pubkey() (crypto.PubKey, error) {
+	return s.privKey.GetPublic(), nil
+}
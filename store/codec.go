@@ -0,0 +1,44 @@
+package store
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// marshalHeader/unmarshalHeader and marshalData/unmarshalData use gob rather
+// than protobuf: the store package only needs a stable on-disk shape, and
+// the wire format is never exposed outside this package.
+
+func marshalHeader(h *types.SignedHeader) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(h); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalHeader(raw []byte) (*types.SignedHeader, error) {
+	var h types.SignedHeader
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func marshalData(d *types.Data) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalData(raw []byte) (*types.Data, error) {
+	var d types.Data
+	if err := gob.NewDecoder(bytes.NewReader(raw)).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,230 @@
+// Package store implements the persistence layer used by the rollkit block
+// manager: headers, data, commits, state and auxiliary per-height metadata,
+// all keyed by height on top of an ipfs go-datastore.
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+var (
+	blockSourcePrefix   = ds.NewKey("/blocksource")
+	headerPrefix        = ds.NewKey("/header")
+	dataPrefix          = ds.NewKey("/data")
+	commitPrefix        = ds.NewKey("/commit")
+	stateResponsePrefix = ds.NewKey("/stateresponse")
+	heightKey           = ds.NewKey("/height")
+
+	// ErrBlockNotFound is returned by GetBlock when no block, or a pruned
+	// one, is requested.
+	ErrBlockNotFound = fmt.Errorf("block not found")
+)
+
+// Store persists the chain state produced by the block manager.
+type Store interface {
+	// Height returns the height of the last saved block.
+	Height(ctx context.Context) (uint64, error)
+
+	// SaveBlock persists block's header, data and commit, and advances the
+	// store height if block.Height() is greater than the current height.
+	SaveBlock(ctx context.Context, block *types.Block, commit []byte) error
+
+	// GetBlock reconstructs the block at height from its header and data.
+	// It returns ErrBlockNotFound if the height was never saved, or has
+	// since been pruned.
+	GetBlock(ctx context.Context, height uint64) (*types.Block, error)
+
+	// SaveStateResponse persists the execution environment's response for
+	// height, used on restart to avoid re-executing already-applied blocks.
+	SaveStateResponse(ctx context.Context, height uint64, resp []byte) error
+
+	// SaveBlockSource records the provenance of the block applied at the
+	// given height: whether it was produced locally, received over P2P, or
+	// retrieved from the DA layer.
+	SaveBlockSource(ctx context.Context, height uint64, source types.BlockSource) error
+
+	// GetBlockSource returns the provenance previously recorded for height
+	// via SaveBlockSource. It returns types.SourceUnknown, nil if nothing
+	// was ever recorded for that height.
+	GetBlockSource(ctx context.Context, height uint64) (types.BlockSource, error)
+
+	// PruneBlocks deletes the header, data, commit, state response and
+	// block source recorded below retainHeight. Heights >= retainHeight are
+	// left untouched. It returns the number of heights pruned.
+	PruneBlocks(ctx context.Context, retainHeight uint64) (uint64, error)
+}
+
+// DefaultStore is the ipfs go-datastore backed implementation of Store used
+// by production nodes and by the test suites.
+type DefaultStore struct {
+	db ds.Datastore
+}
+
+// New creates a DefaultStore on top of the given datastore.
+func New(db ds.Datastore) *DefaultStore {
+	return &DefaultStore{db: db}
+}
+
+// Height returns the height of the last saved block.
+func (s *DefaultStore) Height(ctx context.Context) (uint64, error) {
+	raw, err := s.db.Get(ctx, heightKey)
+	if err != nil {
+		if err == ds.ErrNotFound {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(raw), nil
+}
+
+func heightIndexKey(prefix ds.Key, height uint64) ds.Key {
+	return prefix.ChildString(fmt.Sprintf("%020d", height))
+}
+
+func blockSourceKey(height uint64) ds.Key {
+	return heightIndexKey(blockSourcePrefix, height)
+}
+
+// SaveBlock persists block's header, data and commit, and advances the
+// store height.
+func (s *DefaultStore) SaveBlock(ctx context.Context, block *types.Block, commit []byte) error {
+	height := block.Height()
+
+	header, err := marshalHeader(&block.SignedHeader)
+	if err != nil {
+		return fmt.Errorf("marshaling header at height %d: %w", height, err)
+	}
+	if err := s.db.Put(ctx, heightIndexKey(headerPrefix, height), header); err != nil {
+		return fmt.Errorf("saving header at height %d: %w", height, err)
+	}
+
+	data, err := marshalData(&block.Data)
+	if err != nil {
+		return fmt.Errorf("marshaling data at height %d: %w", height, err)
+	}
+	if err := s.db.Put(ctx, heightIndexKey(dataPrefix, height), data); err != nil {
+		return fmt.Errorf("saving data at height %d: %w", height, err)
+	}
+
+	if err := s.db.Put(ctx, heightIndexKey(commitPrefix, height), commit); err != nil {
+		return fmt.Errorf("saving commit at height %d: %w", height, err)
+	}
+
+	current, err := s.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height > current {
+		buf := make([]byte, 8)
+		binary.BigEndian.PutUint64(buf, height)
+		if err := s.db.Put(ctx, heightKey, buf); err != nil {
+			return fmt.Errorf("advancing store height to %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// GetBlock reconstructs the block at height, or returns ErrBlockNotFound if
+// it was never saved or has been pruned.
+func (s *DefaultStore) GetBlock(ctx context.Context, height uint64) (*types.Block, error) {
+	rawHeader, err := s.db.Get(ctx, heightIndexKey(headerPrefix, height))
+	if err != nil {
+		if err == ds.ErrNotFound {
+			return nil, ErrBlockNotFound
+		}
+		return nil, err
+	}
+	rawData, err := s.db.Get(ctx, heightIndexKey(dataPrefix, height))
+	if err != nil {
+		if err == ds.ErrNotFound {
+			return nil, ErrBlockNotFound
+		}
+		return nil, err
+	}
+
+	header, err := unmarshalHeader(rawHeader)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling header at height %d: %w", height, err)
+	}
+	data, err := unmarshalData(rawData)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling data at height %d: %w", height, err)
+	}
+
+	return &types.Block{SignedHeader: *header, Data: *data}, nil
+}
+
+// SaveStateResponse persists the execution environment's response for
+// height.
+func (s *DefaultStore) SaveStateResponse(ctx context.Context, height uint64, resp []byte) error {
+	return s.db.Put(ctx, heightIndexKey(stateResponsePrefix, height), resp)
+}
+
+// PruneBlocks deletes everything recorded below retainHeight and returns
+// the number of heights that actually had something to delete.
+func (s *DefaultStore) PruneBlocks(ctx context.Context, retainHeight uint64) (uint64, error) {
+	current, err := s.Height(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if retainHeight > current {
+		retainHeight = current
+	}
+
+	var pruned uint64
+	for h := uint64(1); h < retainHeight; h++ {
+		var deletedAny bool
+		for _, key := range []ds.Key{
+			heightIndexKey(headerPrefix, h),
+			heightIndexKey(dataPrefix, h),
+			heightIndexKey(commitPrefix, h),
+			heightIndexKey(stateResponsePrefix, h),
+			heightIndexKey(blockSourcePrefix, h),
+		} {
+			has, err := s.db.Has(ctx, key)
+			if err != nil {
+				return pruned, err
+			}
+			if !has {
+				continue
+			}
+			if err := s.db.Delete(ctx, key); err != nil {
+				return pruned, err
+			}
+			deletedAny = true
+		}
+		if deletedAny {
+			pruned++
+		}
+	}
+
+	return pruned, nil
+}
+
+// SaveBlockSource records the provenance of the block applied at height.
+func (s *DefaultStore) SaveBlockSource(ctx context.Context, height uint64, source types.BlockSource) error {
+	return s.db.Put(ctx, blockSourceKey(height), []byte{byte(source)})
+}
+
+// GetBlockSource returns the provenance recorded for height, or
+// types.SourceUnknown if none was recorded.
+func (s *DefaultStore) GetBlockSource(ctx context.Context, height uint64) (types.BlockSource, error) {
+	raw, err := s.db.Get(ctx, blockSourceKey(height))
+	if err != nil {
+		if err == ds.ErrNotFound {
+			return types.SourceUnknown, nil
+		}
+		return types.SourceUnknown, err
+	}
+	if len(raw) != 1 {
+		return types.SourceUnknown, fmt.Errorf("corrupt block source value at height %d", height)
+	}
+	return types.BlockSource(raw[0]), nil
+}
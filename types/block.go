@@ -0,0 +1,96 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"time"
+)
+
+// Header contains the minimal set of fields rollkit needs to identify and
+// verify a block without processing its full data.
+type Header struct {
+	Height          uint64
+	Time            uint64
+	ChainID         string
+	AppHash         []byte
+	DataHash        []byte
+	ProposerAddress []byte
+}
+
+// SignedHeader is a Header together with the commit signature that proves
+// it was produced (or accepted) by the chain's validator set.
+type SignedHeader struct {
+	Header
+	Signature  []byte
+	Validators []byte
+}
+
+// Hash returns the header's content commitment: the hash that gets signed,
+// covering every field a light client needs to trust the header on
+// (Height, Time, ChainID, AppHash, DataHash and ProposerAddress). This is
+// deliberately distinct from Block.Hash, which is a cheaper dedup/cache key
+// derived from only the height and DataHash and excludes AppHash, so it
+// must never be used as the signing payload.
+func (h *Header) Hash() []byte {
+	hasher := sha256.New()
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, h.Height)
+	hasher.Write(buf)
+	binary.BigEndian.PutUint64(buf, h.Time)
+	hasher.Write(buf)
+	hasher.Write([]byte(h.ChainID))
+	hasher.Write(h.AppHash)
+	hasher.Write(h.DataHash)
+	hasher.Write(h.ProposerAddress)
+	return hasher.Sum(nil)
+}
+
+// Data carries the transactions included in a block, stored separately from
+// the Header so that light clients can sync headers without the payload.
+type Data struct {
+	Txs [][]byte
+}
+
+// Hash returns the content hash of the data, used to populate
+// Header.DataHash. Each tx is length-prefixed so that, e.g., Txs{"ab","c"}
+// and Txs{"a","bc"} don't collide on their concatenation.
+func (d *Data) Hash() []byte {
+	h := sha256.New()
+	buf := make([]byte, 8)
+	for _, tx := range d.Txs {
+		binary.BigEndian.PutUint64(buf, uint64(len(tx)))
+		h.Write(buf)
+		h.Write(tx)
+	}
+	return h.Sum(nil)
+}
+
+// Block is the full block as applied by the block manager: a signed header
+// plus its data.
+type Block struct {
+	SignedHeader SignedHeader
+	Data         Data
+}
+
+// Height returns the block's height, delegating to the embedded header.
+func (b *Block) Height() uint64 {
+	return b.SignedHeader.Header.Height
+}
+
+// Hash returns the block's content hash, used as the cache/dedup key. It
+// commits to the height as well as the header's DataHash so that blocks at
+// different heights never collide, even if their data is identical (e.g.
+// two empty blocks).
+func (b *Block) Hash() []byte {
+	h := sha256.New()
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, b.Height())
+	h.Write(buf)
+	h.Write(b.SignedHeader.Header.DataHash)
+	return h.Sum(nil)
+}
+
+// Time returns the block's wall-clock timestamp.
+func (b *Block) Time() time.Time {
+	return time.Unix(0, int64(b.SignedHeader.Header.Time))
+}
@@ -0,0 +1,33 @@
+package types
+
+// BlockSource identifies how a block came to be applied by the block
+// manager: produced locally by the aggregator, received over the P2P
+// gossip network, or retrieved from the DA layer.
+type BlockSource uint8
+
+const (
+	// SourceUnknown is the zero value, used when no provenance was recorded
+	// (e.g. for blocks applied before this tracking was introduced).
+	SourceUnknown BlockSource = iota
+	// SourceProduced marks a block this node produced itself as aggregator.
+	SourceProduced
+	// SourceP2P marks a block received via the P2P block-sync gossip path.
+	SourceP2P
+	// SourceDA marks a block retrieved from the DA layer, i.e. hard
+	// confirmed.
+	SourceDA
+)
+
+// String returns a human-readable name for the source, used in logs.
+func (s BlockSource) String() string {
+	switch s {
+	case SourceProduced:
+		return "produced"
+	case SourceP2P:
+		return "p2p"
+	case SourceDA:
+		return "da"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// Genesis holds the initial chain parameters a rollkit node is bootstrapped
+// with.
+type Genesis struct {
+	ChainID         string
+	GenesisTime     time.Time
+	InitialHeight   uint64
+	ProposerAddress []byte
+}
+
+// GetGenesisWithPrivkey builds a single-validator test genesis for the given
+// chain ID, along with the validator's freshly generated private key. It is
+// only intended for use in tests.
+func GetGenesisWithPrivkey(chainID string) (Genesis, crypto.PrivKey, error) {
+	privKey, _, err := crypto.GenerateEd25519Key(nil)
+	if err != nil {
+		return Genesis{}, nil, err
+	}
+
+	pubKey := privKey.GetPublic()
+	addr, err := crypto.MarshalPublicKey(pubKey)
+	if err != nil {
+		return Genesis{}, nil, err
+	}
+
+	genesis := Genesis{
+		ChainID:         chainID,
+		GenesisTime:     time.Now(),
+		InitialHeight:   1,
+		ProposerAddress: addr,
+	}
+
+	return genesis, privKey, nil
+}